@@ -1,28 +1,84 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"io"
 	"net/http"
 	"sync"
 	"time"
 )
 
-func checkUrl(url string, wg *sync.WaitGroup, results chan<- string) {
+// checkUrl probes a single URL using client, retrying per policy on
+// network errors, 5xx, and 429, and sends a structured CheckResult on
+// results. Each attempt's latency is recorded in the result so backoff
+// behavior is visible in the report. It returns promptly if ctx is
+// canceled.
+func checkUrl(ctx context.Context, url string, wg *sync.WaitGroup, results chan<- CheckResult, policy RetryPolicy, client *http.Client) {
 	defer wg.Done() // 1. Signal completion
 
-	// 2. Create a Client with Timeout
-	client := http.Client{
-		Timeout: 5 * time.Second,
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
 	}
 
-	// 3. Perform the Request
-	resp, err := client.Get(url)
-	if err != nil {
-		results <- fmt.Sprintf("[FAIL] %s - Error: %v", url, err)
-		return
+	var attempts []AttemptResult
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		// 2. Perform the Request, tracing which phase (dial/TLS/headers/
+		// body) is in flight so a timeout error can say where it happened.
+		tracker := &phaseTracker{}
+		tracedCtx := tracker.withTrace(ctx)
+
+		start := time.Now()
+		req, err := http.NewRequestWithContext(tracedCtx, http.MethodGet, url, nil)
+		if err != nil {
+			results <- newCheckResult(url, 0, time.Since(start), err, attempts)
+			return
+		}
+		resp, err := client.Do(req)
+		latency := time.Since(start)
+
+		statusCode := 0
+		var retryAfter string
+		var bodyErr error
+		if resp != nil {
+			statusCode = resp.StatusCode
+			retryAfter = resp.Header.Get("Retry-After")
+			// 3. Drain the body fully so the connection returns to the
+			// pool, then close it.
+			_, bodyErr = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err == nil {
+			err = bodyErr
+		}
+
+		ar := AttemptResult{StatusCode: statusCode, Latency: latency, Error: errString(err)}
+		if err != nil {
+			ar.Phase = string(tracker.phase(resp != nil))
+		}
+		attempts = append(attempts, ar)
+
+		retryable := err != nil || retryableStatus(statusCode)
+		if !retryable || attempt == maxAttempts {
+			// 4. Send the classified result
+			results <- newCheckResult(url, statusCode, latency, err, attempts)
+			return
+		}
+
+		delay := backoffDelay(policy, attempt)
+		if d, ok := parseRetryAfter(retryAfter, time.Now()); ok {
+			delay = d
+		}
+		if sleepErr := sleepOrDone(ctx, delay); sleepErr != nil {
+			results <- newCheckResult(url, statusCode, latency, sleepErr, attempts)
+			return
+		}
 	}
-	defer resp.Body.Close() // 4. Cleanup
+}
 
-	// 5. Send Success
-	results <- fmt.Sprintf("[%s] %s - Status: %d", "SUCCESS", url, resp.StatusCode)
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
 }
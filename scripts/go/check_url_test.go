@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy mirrors DefaultRetryPolicy's retry conditions but with
+// short delays so retry tests don't pay real backoff wall-clock time.
+func fastRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		Jitter:      false,
+	}
+}
+
+func runCheckUrl(t *testing.T, url string, policy RetryPolicy) CheckResult {
+	t.Helper()
+	var wg sync.WaitGroup
+	results := make(chan CheckResult, 1)
+	wg.Add(1)
+	go checkUrl(context.Background(), url, &wg, results, policy, http.DefaultClient)
+	wg.Wait()
+	return <-results
+}
+
+func TestCheckUrlRetriesOn5xxThenSucceeds(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	r := runCheckUrl(t, server.URL, fastRetryPolicy(4))
+
+	if r.Kind != ErrorKindNone {
+		t.Fatalf("result.Kind = %v, want %v (got: %+v)", r.Kind, ErrorKindNone, r)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("server saw %d requests, want 3", got)
+	}
+	if len(r.Attempts) != 3 {
+		t.Fatalf("len(Attempts) = %d, want 3: %+v", len(r.Attempts), r.Attempts)
+	}
+	for i, a := range r.Attempts {
+		wantStatus := http.StatusInternalServerError
+		if i == 2 {
+			wantStatus = http.StatusOK
+		}
+		if a.StatusCode != wantStatus {
+			t.Errorf("Attempts[%d].StatusCode = %d, want %d", i, a.StatusCode, wantStatus)
+		}
+	}
+}
+
+func TestCheckUrlGivesUpAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	r := runCheckUrl(t, server.URL, fastRetryPolicy(3))
+
+	if r.Kind != ErrorKindHTTP5xx {
+		t.Fatalf("result.Kind = %v, want %v", r.Kind, ErrorKindHTTP5xx)
+	}
+	if len(r.Attempts) != 3 {
+		t.Fatalf("len(Attempts) = %d, want 3 (MaxAttempts): %+v", len(r.Attempts), r.Attempts)
+	}
+}
+
+func TestCheckUrlHonorsRetryAfterHeader(t *testing.T) {
+	var attempts int32
+	var firstAttemptAt time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			firstAttemptAt = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// BaseDelay is tiny so any observed delay before the retry comes from
+	// the Retry-After header, not the backoff policy.
+	policy := fastRetryPolicy(2)
+	r := runCheckUrl(t, server.URL, policy)
+
+	if r.Kind != ErrorKindNone {
+		t.Fatalf("result.Kind = %v, want %v (got: %+v)", r.Kind, ErrorKindNone, r)
+	}
+	if elapsed := time.Since(firstAttemptAt); elapsed < 900*time.Millisecond {
+		t.Errorf("second attempt arrived after %v, want to honor Retry-After: 1 (~1s)", elapsed)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// ClientConfig controls the *http.Transport backing a shared client so
+// callers probing many URLs keep connection pooling and keep-alives
+// instead of paying a fresh TCP/TLS handshake per check.
+type ClientConfig struct {
+	Timeout               time.Duration // overall per-request timeout
+	MaxIdleConns          int
+	MaxIdleConnsPerHost   int
+	MaxConnsPerHost       int
+	IdleConnTimeout       time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	ExpectContinueTimeout time.Duration
+	DisableCompression    bool
+}
+
+// DefaultClientConfig mirrors net/http's DefaultTransport pool and
+// handshake defaults, adds a 5s overall and response-header timeout, and
+// raises the per-host idle cap so probing the same host repeatedly
+// reuses connections instead of exhausting the pool.
+func DefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		Timeout:               5 * time.Second,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   20,
+		MaxConnsPerHost:       0,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 5 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// NewHTTPClient builds an *http.Client with a Transport tuned per cfg.
+func NewHTTPClient(cfg ClientConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		DisableCompression:    cfg.DisableCompression,
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   cfg.Timeout,
+	}
+}
+
+// defaultClient is shared across checks so connection pools and
+// keep-alives persist across calls instead of being discarded per
+// request.
+var defaultClient = NewHTTPClient(DefaultClientConfig())
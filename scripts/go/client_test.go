@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDefaultClientConfigRaisesPerHostIdleCap(t *testing.T) {
+	cfg := DefaultClientConfig()
+	if cfg.MaxIdleConnsPerHost <= http.DefaultMaxIdleConnsPerHost {
+		t.Errorf("DefaultClientConfig().MaxIdleConnsPerHost = %d, want > net/http default (%d)", cfg.MaxIdleConnsPerHost, http.DefaultMaxIdleConnsPerHost)
+	}
+}
+
+func TestNewHTTPClientAppliesConfig(t *testing.T) {
+	cfg := ClientConfig{
+		Timeout:             3 * time.Second,
+		MaxIdleConns:        5,
+		MaxIdleConnsPerHost: 2,
+		IdleConnTimeout:     time.Minute,
+	}
+	client := NewHTTPClient(cfg)
+
+	if client.Timeout != cfg.Timeout {
+		t.Errorf("client.Timeout = %v, want %v", client.Timeout, cfg.Timeout)
+	}
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("client.Transport is %T, want *http.Transport", client.Transport)
+	}
+	if transport.MaxIdleConns != cfg.MaxIdleConns {
+		t.Errorf("transport.MaxIdleConns = %d, want %d", transport.MaxIdleConns, cfg.MaxIdleConns)
+	}
+	if transport.MaxIdleConnsPerHost != cfg.MaxIdleConnsPerHost {
+		t.Errorf("transport.MaxIdleConnsPerHost = %d, want %d", transport.MaxIdleConnsPerHost, cfg.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != cfg.IdleConnTimeout {
+		t.Errorf("transport.IdleConnTimeout = %v, want %v", transport.IdleConnTimeout, cfg.IdleConnTimeout)
+	}
+}
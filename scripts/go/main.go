@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Run checks every URL through pool, bounding overall and per-host
+// concurrency, and hands the collected results to reporter, writing its
+// output to stdout. Each check retries per DefaultRetryPolicy and aborts
+// if ctx is done.
+func Run(ctx context.Context, urls []string, reporter Reporter, pool *Pool) error {
+	collected := make([]CheckResult, 0, len(urls))
+	for r := range pool.CheckAll(ctx, urls) {
+		collected = append(collected, r)
+	}
+
+	return reporter.Report(os.Stdout, collected)
+}
+
+func reporterFor(name string) (Reporter, error) {
+	switch name {
+	case "json":
+		return JSONReporter{}, nil
+	case "text":
+		return TextReporter{}, nil
+	case "prometheus":
+		return PrometheusReporter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown reporter %q (want json, text, or prometheus)", name)
+	}
+}
+
+func main() {
+	urlList := flag.String("urls", "", "comma-separated list of URLs to check")
+	format := flag.String("format", "text", "output format: json, text, or prometheus")
+	maxInFlight := flag.Int("max-in-flight", 10, "maximum number of checks running at once")
+	perHostBurst := flag.Int("per-host-burst", 4, "maximum concurrent checks (and rate-limit burst) against a single host")
+	perHostRPS := flag.Float64("per-host-rps", 0, "maximum requests/sec against a single host, 0 disables rate limiting")
+	flag.Parse()
+
+	if *urlList == "" {
+		fmt.Fprintln(os.Stderr, "usage: check_url -urls=https://a.example,https://b.example [-format=json]")
+		os.Exit(2)
+	}
+
+	reporter, err := reporterFor(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	pool := NewPool(PoolConfig{
+		MaxInFlight:     *maxInFlight,
+		PerHostMaxBurst: *perHostBurst,
+		PerHostRPS:      *perHostRPS,
+	})
+
+	urls := strings.Split(*urlList, ",")
+	if err := Run(context.Background(), urls, reporter, pool); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
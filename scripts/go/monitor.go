@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// EventKind classifies a transition observed by a Monitor.
+type EventKind int
+
+const (
+	EventUp EventKind = iota
+	EventDown
+	EventLatencyRegression
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventUp:
+		return "up"
+	case EventDown:
+		return "down"
+	case EventLatencyRegression:
+		return "latency_regression"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a state change observed while monitoring a URL.
+type Event struct {
+	URL    string
+	Kind   EventKind
+	Result CheckResult
+	When   time.Time
+}
+
+// Notifier is notified whenever a Monitor observes an Event.
+type Notifier func(Event)
+
+// StdoutNotifier prints each event to stdout.
+func StdoutNotifier(e Event) {
+	fmt.Printf("[%s] %s %s\n", e.When.Format(time.RFC3339), e.Kind, e.URL)
+}
+
+// WebhookNotifier returns a Notifier that POSTs each Event as JSON to
+// webhookURL, logging to stdout (via StdoutNotifier) if the POST fails.
+func WebhookNotifier(webhookURL string) Notifier {
+	return func(e Event) {
+		body, err := json.Marshal(e)
+		if err != nil {
+			StdoutNotifier(e)
+			return
+		}
+		resp, err := http.Post(webhookURL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			StdoutNotifier(e)
+			return
+		}
+		resp.Body.Close()
+	}
+}
+
+// MonitorTarget is a single URL to watch at a fixed interval.
+type MonitorTarget struct {
+	URL      string
+	Interval time.Duration
+}
+
+// defaultMonitorInterval is used for any MonitorTarget left at its
+// zero-value Interval, so a caller that forgets to set it gets a sane
+// polling rate instead of handing time.NewTicker a non-positive duration.
+const defaultMonitorInterval = 30 * time.Second
+
+// MonitorConfig configures a Monitor.
+type MonitorConfig struct {
+	Targets               []MonitorTarget
+	HistorySize           int           // how many recent statuses to retain per URL, default 20
+	LatencyRegressionOver time.Duration // emit EventLatencyRegression if latency exceeds this, 0 disables
+	Notifier              Notifier      // defaults to StdoutNotifier
+}
+
+// urlState is the rolling window of recent checks for one URL.
+type urlState struct {
+	mu      sync.Mutex
+	history []CheckResult // most recent last
+	up      bool
+	started bool
+}
+
+func (s *urlState) record(r CheckResult, max int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = append(s.history, r)
+	if len(s.history) > max {
+		s.history = s.history[len(s.history)-max:]
+	}
+}
+
+// uptimePct returns the percentage (0-100) of recorded checks that
+// succeeded.
+func (s *urlState) uptimePct() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.history) == 0 {
+		return 0
+	}
+	ok := 0
+	for _, r := range s.history {
+		if r.Kind == ErrorKindNone {
+			ok++
+		}
+	}
+	return float64(ok) / float64(len(s.history)) * 100
+}
+
+// Monitor repeatedly probes a set of URLs on their configured
+// intervals, keeping rolling per-URL state and emitting Events on
+// UP<->DOWN transitions and latency regressions.
+type Monitor struct {
+	cfg    MonitorConfig
+	states map[string]*urlState
+}
+
+// NewMonitor builds a Monitor from cfg, defaulting HistorySize to 20
+// and Notifier to StdoutNotifier if unset.
+func NewMonitor(cfg MonitorConfig) *Monitor {
+	if cfg.HistorySize <= 0 {
+		cfg.HistorySize = 20
+	}
+	if cfg.Notifier == nil {
+		cfg.Notifier = StdoutNotifier
+	}
+	targets := make([]MonitorTarget, len(cfg.Targets))
+	copy(targets, cfg.Targets)
+	for i := range targets {
+		if targets[i].Interval <= 0 {
+			targets[i].Interval = defaultMonitorInterval
+		}
+	}
+	cfg.Targets = targets
+
+	states := make(map[string]*urlState, len(cfg.Targets))
+	for _, t := range cfg.Targets {
+		states[t.URL] = &urlState{}
+	}
+	return &Monitor{cfg: cfg, states: states}
+}
+
+// Run starts one goroutine per target, each probing its URL on its own
+// interval until ctx is canceled. Run blocks until all target
+// goroutines have exited.
+func (m *Monitor) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, t := range m.cfg.Targets {
+		t := t
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.watch(ctx, t)
+		}()
+	}
+	wg.Wait()
+}
+
+func (m *Monitor) watch(ctx context.Context, t MonitorTarget) {
+	ticker := time.NewTicker(t.Interval)
+	defer ticker.Stop()
+
+	m.probe(ctx, t)
+	for {
+		select {
+		case <-ticker.C:
+			m.probe(ctx, t)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *Monitor) probe(ctx context.Context, t MonitorTarget) {
+	results := make(chan CheckResult, 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go checkUrl(ctx, t.URL, &wg, results, DefaultRetryPolicy(), defaultClient)
+	wg.Wait()
+	r := <-results
+
+	state := m.states[t.URL]
+	prevUp, hadPrev := state.up, state.started
+
+	state.mu.Lock()
+	state.up = r.Kind == ErrorKindNone
+	state.started = true
+	state.mu.Unlock()
+
+	state.record(r, m.cfg.HistorySize)
+
+	now := time.Now()
+	if hadPrev && prevUp != state.up {
+		kind := EventDown
+		if state.up {
+			kind = EventUp
+		}
+		m.cfg.Notifier(Event{URL: t.URL, Kind: kind, Result: r, When: now})
+	}
+
+	if m.cfg.LatencyRegressionOver > 0 && r.Latency > m.cfg.LatencyRegressionOver {
+		m.cfg.Notifier(Event{URL: t.URL, Kind: EventLatencyRegression, Result: r, When: now})
+	}
+}
+
+// Uptime returns the rolling uptime percentage observed for url over
+// its retained history.
+func (m *Monitor) Uptime(url string) float64 {
+	state, ok := m.states[url]
+	if !ok {
+		return 0
+	}
+	return state.uptimePct()
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewMonitorDefaultsInterval(t *testing.T) {
+	m := NewMonitor(MonitorConfig{Targets: []MonitorTarget{{URL: "http://example.invalid"}}})
+	if got := m.cfg.Targets[0].Interval; got != defaultMonitorInterval {
+		t.Errorf("NewMonitor() zero-value Interval = %v, want %v", got, defaultMonitorInterval)
+	}
+}
+
+func TestNewMonitorPreservesExplicitInterval(t *testing.T) {
+	m := NewMonitor(MonitorConfig{Targets: []MonitorTarget{{URL: "http://example.invalid", Interval: 5 * time.Second}}})
+	if got := m.cfg.Targets[0].Interval; got != 5*time.Second {
+		t.Errorf("NewMonitor() explicit Interval = %v, want 5s", got)
+	}
+}
+
+func TestNewMonitorDefaultsHistorySizeAndNotifier(t *testing.T) {
+	m := NewMonitor(MonitorConfig{})
+	if m.cfg.HistorySize != 20 {
+		t.Errorf("NewMonitor({}).cfg.HistorySize = %d, want 20", m.cfg.HistorySize)
+	}
+	if m.cfg.Notifier == nil {
+		t.Error("NewMonitor({}).cfg.Notifier = nil, want StdoutNotifier")
+	}
+}
+
+func TestUrlStateUptimePct(t *testing.T) {
+	s := &urlState{}
+	s.record(CheckResult{Kind: ErrorKindNone}, 10)
+	s.record(CheckResult{Kind: ErrorKindTimeout}, 10)
+	if got := s.uptimePct(); got != 50 {
+		t.Errorf("uptimePct() = %v, want 50", got)
+	}
+}
+
+func TestUrlStateRecordCapsHistoryAtMax(t *testing.T) {
+	s := &urlState{}
+	for i := 0; i < 5; i++ {
+		s.record(CheckResult{}, 3)
+	}
+	if len(s.history) != 3 {
+		t.Errorf("len(history) = %d, want capped at 3", len(s.history))
+	}
+}
+
+func TestMonitorUptimeUnknownURL(t *testing.T) {
+	m := NewMonitor(MonitorConfig{})
+	if got := m.Uptime("http://nope.invalid"); got != 0 {
+		t.Errorf("Uptime(unknown) = %v, want 0", got)
+	}
+}
+
+// fakeNotifier records every Event it receives.
+type fakeNotifier struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (f *fakeNotifier) notify(e Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, e)
+}
+
+func (f *fakeNotifier) snapshot() []Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]Event, len(f.events))
+	copy(out, f.events)
+	return out
+}
+
+func TestMonitorProbeEmitsEventsOnlyOnTransition(t *testing.T) {
+	var up int32 = 1 // atomic-ish via mutex below for clarity
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ok := up == 1
+		mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := &fakeNotifier{}
+	m := NewMonitor(MonitorConfig{
+		Targets:  []MonitorTarget{{URL: server.URL, Interval: time.Hour}},
+		Notifier: notifier.notify,
+	})
+	target := m.cfg.Targets[0]
+
+	// First probe: no prior state, so no event even though the target is up.
+	m.probe(context.Background(), target)
+
+	// Flip down. Use a short-deadline context so the retry backoff built
+	// into checkUrl doesn't make the test wait out several retries.
+	mu.Lock()
+	up = 0
+	mu.Unlock()
+	downCtx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	m.probe(downCtx, target)
+	cancel()
+
+	// Flip back up.
+	mu.Lock()
+	up = 1
+	mu.Unlock()
+	m.probe(context.Background(), target)
+
+	events := notifier.snapshot()
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(events), events)
+	}
+	if events[0].Kind != EventDown {
+		t.Errorf("events[0].Kind = %v, want %v", events[0].Kind, EventDown)
+	}
+	if events[1].Kind != EventUp {
+		t.Errorf("events[1].Kind = %v, want %v", events[1].Kind, EventUp)
+	}
+}
+
+func TestMonitorProbeEmitsLatencyRegression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := &fakeNotifier{}
+	m := NewMonitor(MonitorConfig{
+		Targets:               []MonitorTarget{{URL: server.URL, Interval: time.Hour}},
+		Notifier:              notifier.notify,
+		LatencyRegressionOver: 5 * time.Millisecond,
+	})
+
+	m.probe(context.Background(), m.cfg.Targets[0])
+
+	events := notifier.snapshot()
+	if len(events) != 1 || events[0].Kind != EventLatencyRegression {
+		t.Fatalf("events = %+v, want a single EventLatencyRegression", events)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+)
+
+// requestPhase identifies which stage of an HTTP round trip was in
+// flight when a request failed, so a timeout error can say *where* it
+// happened rather than just that it happened.
+type requestPhase string
+
+const (
+	phaseDial    requestPhase = "dial"
+	phaseTLS     requestPhase = "tls"
+	phaseHeaders requestPhase = "headers"
+	phaseBody    requestPhase = "body"
+	phaseUnknown requestPhase = "unknown"
+)
+
+// phaseTracker records which connection phases have started and
+// finished for a single request via httptrace.ClientTrace hooks.
+type phaseTracker struct {
+	mu sync.Mutex
+
+	dialStarted, dialDone bool
+	tlsStarted, tlsDone   bool
+	wroteRequest          bool
+	gotFirstResponseByte  bool
+}
+
+// withTrace attaches a ClientTrace to ctx that updates t as the
+// request progresses through dial, TLS, and header phases.
+func (t *phaseTracker) withTrace(ctx context.Context) context.Context {
+	trace := &httptrace.ClientTrace{
+		ConnectStart: func(_, _ string) {
+			t.mu.Lock()
+			t.dialStarted = true
+			t.mu.Unlock()
+		},
+		ConnectDone: func(_, _ string, _ error) {
+			t.mu.Lock()
+			t.dialDone = true
+			t.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			t.tlsStarted = true
+			t.mu.Unlock()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, _ error) {
+			t.mu.Lock()
+			t.tlsDone = true
+			t.mu.Unlock()
+		},
+		WroteRequest: func(_ httptrace.WroteRequestInfo) {
+			t.mu.Lock()
+			t.wroteRequest = true
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			t.gotFirstResponseByte = true
+			t.mu.Unlock()
+		},
+	}
+	return httptrace.WithClientTrace(ctx, trace)
+}
+
+// phase reports the last phase that had started but not yet completed
+// when the request stopped progressing, for attribution in error
+// metadata. If no dial has started at all it reports phaseDial.
+// bodyStarted should be true once the caller has begun reading the
+// response body.
+func (t *phaseTracker) phase(bodyStarted bool) requestPhase {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if bodyStarted {
+		return phaseBody
+	}
+	if t.wroteRequest && !t.gotFirstResponseByte {
+		return phaseHeaders
+	}
+	if t.tlsStarted && !t.tlsDone {
+		return phaseTLS
+	}
+	if t.dialStarted && !t.dialDone {
+		return phaseDial
+	}
+	if !t.dialStarted {
+		return phaseDial
+	}
+	return phaseUnknown
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"testing"
+)
+
+func TestPhaseTrackerZeroValue(t *testing.T) {
+	tr := &phaseTracker{}
+	if got := tr.phase(false); got != phaseDial {
+		t.Errorf("phase(false) on zero value = %v, want %v", got, phaseDial)
+	}
+}
+
+func TestPhaseTrackerDialInFlight(t *testing.T) {
+	tr := &phaseTracker{dialStarted: true}
+	if got := tr.phase(false); got != phaseDial {
+		t.Errorf("phase() with dial started, not done = %v, want %v", got, phaseDial)
+	}
+}
+
+func TestPhaseTrackerTLSInFlight(t *testing.T) {
+	tr := &phaseTracker{dialStarted: true, dialDone: true, tlsStarted: true}
+	if got := tr.phase(false); got != phaseTLS {
+		t.Errorf("phase() with TLS started, not done = %v, want %v", got, phaseTLS)
+	}
+}
+
+func TestPhaseTrackerHeadersInFlight(t *testing.T) {
+	tr := &phaseTracker{dialStarted: true, dialDone: true, tlsStarted: true, tlsDone: true, wroteRequest: true}
+	if got := tr.phase(false); got != phaseHeaders {
+		t.Errorf("phase() with request written, no response byte = %v, want %v", got, phaseHeaders)
+	}
+}
+
+func TestPhaseTrackerDialDoneNothingWrittenIsUnknown(t *testing.T) {
+	// Connection established but nothing written yet (no TLS, e.g. plain
+	// HTTP): the tracker has no phase in flight to attribute, so it
+	// reports phaseUnknown rather than guessing phaseHeaders.
+	tr := &phaseTracker{dialStarted: true, dialDone: true}
+	if got := tr.phase(false); got != phaseUnknown {
+		t.Errorf("phase() with dial done, nothing written = %v, want %v", got, phaseUnknown)
+	}
+}
+
+func TestPhaseTrackerBodyOverridesEverything(t *testing.T) {
+	tr := &phaseTracker{dialStarted: true, dialDone: true, tlsStarted: true, tlsDone: true, wroteRequest: true, gotFirstResponseByte: true}
+	if got := tr.phase(true); got != phaseBody {
+		t.Errorf("phase(bodyStarted=true) = %v, want %v", got, phaseBody)
+	}
+}
+
+func TestPhaseTrackerWithTraceUpdatesState(t *testing.T) {
+	tr := &phaseTracker{}
+	ctx := tr.withTrace(context.Background())
+
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace == nil {
+		t.Fatal("withTrace did not attach a ClientTrace to the context")
+	}
+	trace.ConnectStart("tcp", "example.com:443")
+	trace.ConnectDone("tcp", "example.com:443", nil)
+	trace.TLSHandshakeStart()
+	trace.TLSHandshakeDone(tls.ConnectionState{}, nil)
+	trace.WroteRequest(httptrace.WroteRequestInfo{})
+	trace.GotFirstResponseByte()
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if !tr.dialStarted || !tr.dialDone || !tr.tlsStarted || !tr.tlsDone || !tr.wroteRequest || !tr.gotFirstResponseByte {
+		t.Errorf("trace hooks did not update tracker state: %+v", tr)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// hostLimiter bounds traffic to a single host: sem caps the number of
+// in-flight requests, while limiter enforces a requests-per-second rate
+// with burst via golang.org/x/time/rate, which computes wait times
+// analytically instead of polling a ticker, so it never needs a
+// positive interval and can't panic regardless of how PerHostRPS is set.
+type hostLimiter struct {
+	sem     chan struct{}
+	limiter *rate.Limiter // nil when rps <= 0, i.e. rate limiting is disabled
+}
+
+func newHostLimiter(maxInFlight, burst int, rps float64) *hostLimiter {
+	hl := &hostLimiter{sem: make(chan struct{}, maxInFlight)}
+	if rps > 0 {
+		hl.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+	return hl
+}
+
+func (hl *hostLimiter) acquire(ctx context.Context) error {
+	select {
+	case hl.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if hl.limiter == nil {
+		return nil
+	}
+
+	if err := hl.limiter.Wait(ctx); err != nil {
+		<-hl.sem
+		return err
+	}
+	return nil
+}
+
+func (hl *hostLimiter) release() {
+	<-hl.sem
+}
+
+// PoolConfig controls the concurrency and per-host rate limiting
+// applied by a Pool.
+type PoolConfig struct {
+	MaxInFlight     int     // global cap on concurrent checks
+	PerHostMaxBurst int     // per-host concurrency / burst cap
+	PerHostRPS      float64 // per-host requests/sec, 0 disables rate limiting
+}
+
+// Pool runs checks against a bounded number of URLs at once, applying
+// an independent rate limit per host so probing many URLs on the same
+// host doesn't overwhelm it while different hosts proceed in parallel.
+type Pool struct {
+	cfg   PoolConfig
+	sem   chan struct{}
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+// NewPool builds a Pool from cfg, defaulting MaxInFlight and
+// PerHostMaxBurst to 1 if unset.
+func NewPool(cfg PoolConfig) *Pool {
+	if cfg.MaxInFlight <= 0 {
+		cfg.MaxInFlight = 1
+	}
+	if cfg.PerHostMaxBurst <= 0 {
+		cfg.PerHostMaxBurst = 1
+	}
+	return &Pool{
+		cfg:   cfg,
+		sem:   make(chan struct{}, cfg.MaxInFlight),
+		hosts: make(map[string]*hostLimiter),
+	}
+}
+
+func (p *Pool) limiterFor(rawURL string) *hostLimiter {
+	key := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		key = u.Host
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hl, ok := p.hosts[key]
+	if !ok {
+		hl = newHostLimiter(p.cfg.PerHostMaxBurst, p.cfg.PerHostMaxBurst, p.cfg.PerHostRPS)
+		p.hosts[key] = hl
+	}
+	return hl
+}
+
+// Check runs a single bounded, rate-limited check against u.
+func (p *Pool) Check(ctx context.Context, u string) (CheckResult, error) {
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return CheckResult{}, ctx.Err()
+	}
+	defer func() { <-p.sem }()
+
+	hl := p.limiterFor(u)
+	if err := hl.acquire(ctx); err != nil {
+		return CheckResult{}, err
+	}
+	defer hl.release()
+
+	results := make(chan CheckResult, 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go checkUrl(ctx, u, &wg, results, DefaultRetryPolicy(), defaultClient)
+	wg.Wait()
+	return <-results, nil
+}
+
+// CheckAll runs Check for every URL, respecting the Pool's concurrency
+// and per-host rate limits, and streams results on the returned
+// channel as they complete. The channel is closed once every URL has
+// been checked or ctx is done.
+func (p *Pool) CheckAll(ctx context.Context, urls []string) <-chan CheckResult {
+	out := make(chan CheckResult)
+
+	go func() {
+		defer close(out)
+
+		var wg sync.WaitGroup
+		for _, u := range urls {
+			u := u
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r, err := p.Check(ctx, u)
+				if err != nil {
+					r = newCheckResult(u, 0, 0, err, nil)
+				}
+				select {
+				case out <- r:
+				case <-ctx.Done():
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+
+	return out
+}
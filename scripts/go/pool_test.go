@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewPoolDefaults(t *testing.T) {
+	p := NewPool(PoolConfig{})
+	if cap(p.sem) != 1 {
+		t.Errorf("NewPool({}).sem cap = %d, want 1", cap(p.sem))
+	}
+	if p.cfg.PerHostMaxBurst != 1 {
+		t.Errorf("NewPool({}).cfg.PerHostMaxBurst = %d, want 1", p.cfg.PerHostMaxBurst)
+	}
+}
+
+func TestHostLimiterDisabledNeverBlocks(t *testing.T) {
+	hl := newHostLimiter(2, 2, 0)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if err := hl.acquire(ctx); err != nil {
+			t.Fatalf("acquire %d: %v", i, err)
+		}
+		hl.release()
+	}
+}
+
+func TestHostLimiterRateLimitsBeyondBurst(t *testing.T) {
+	hl := newHostLimiter(3, 1, 5) // 1-token burst, refilled every 200ms
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := hl.acquire(ctx); err != nil {
+			t.Fatalf("acquire %d: %v", i, err)
+		}
+		hl.release()
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("3 acquires with burst=1 rps=5 took %v, want to be rate limited", elapsed)
+	}
+}
+
+func TestHostLimiterAcquireRespectsContextCancel(t *testing.T) {
+	hl := newHostLimiter(2, 1, 0.001) // burst=1 spent immediately, next token refills far in the future
+	ctx := context.Background()
+	if err := hl.acquire(ctx); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+	hl.release()
+
+	shortCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := hl.acquire(shortCtx); err == nil {
+		t.Error("acquire() with exhausted burst and a short deadline = nil, want an error")
+	}
+}
+
+func TestHostLimiterRPSOutOfRangeDoesNotPanic(t *testing.T) {
+	// Extreme PerHostRPS values used to overflow a ticker interval
+	// computed as time.Duration(float64(time.Second)/rps) and panic
+	// time.NewTicker; golang.org/x/time/rate computes wait times
+	// analytically and tolerates both ends of the range.
+	for _, rps := range []float64{2e9, 1e-300} {
+		hl := newHostLimiter(1, 1, rps)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		_ = hl.acquire(ctx)
+		cancel()
+	}
+}
+
+func TestPoolCheckSuccessfulRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := NewPool(PoolConfig{MaxInFlight: 2, PerHostMaxBurst: 2})
+	r, err := p.Check(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if r.Kind != ErrorKindNone {
+		t.Errorf("Check().Kind = %v, want %v", r.Kind, ErrorKindNone)
+	}
+}
+
+func TestPoolCheckAllReturnsEveryURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL, server.URL, server.URL}
+	p := NewPool(PoolConfig{MaxInFlight: 2, PerHostMaxBurst: 2})
+
+	results := make([]CheckResult, 0, len(urls))
+	for r := range p.CheckAll(context.Background(), urls) {
+		results = append(results, r)
+	}
+	if len(results) != len(urls) {
+		t.Errorf("CheckAll() returned %d results, want %d", len(results), len(urls))
+	}
+}
+
+func TestPoolBoundsMaxInFlight(t *testing.T) {
+	var inFlight, maxSeen int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+			if n <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	urls := make([]string, 6)
+	for i := range urls {
+		urls[i] = server.URL
+	}
+
+	p := NewPool(PoolConfig{MaxInFlight: 2, PerHostMaxBurst: 6})
+	for range p.CheckAll(context.Background(), urls) {
+	}
+
+	if got := atomic.LoadInt32(&maxSeen); got > 2 {
+		t.Errorf("observed %d concurrent requests, want <= MaxInFlight (2)", got)
+	}
+}
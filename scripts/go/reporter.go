@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Reporter emits a batch of CheckResults in some output format.
+type Reporter interface {
+	Report(w io.Writer, results []CheckResult) error
+}
+
+// Summary is the aggregated view of a batch of checks, grouping counts
+// by ErrorKind so a caller can see overall health at a glance.
+type Summary struct {
+	Status  string         `json:"status"`
+	Total   int            `json:"total"`
+	Failed  int            `json:"failed"`
+	ByKind  map[string]int `json:"by_kind"`
+	Results []CheckResult  `json:"results"`
+}
+
+func summarize(results []CheckResult) Summary {
+	s := Summary{
+		Total:   len(results),
+		ByKind:  make(map[string]int),
+		Results: results,
+	}
+	for _, r := range results {
+		if r.Kind != ErrorKindNone {
+			s.Failed++
+		}
+		s.ByKind[r.KindName]++
+	}
+	if s.Failed == 0 {
+		s.Status = "ok"
+	} else if s.Failed == s.Total {
+		s.Status = "down"
+	} else {
+		s.Status = "degraded"
+	}
+	return s
+}
+
+// JSONReporter writes the aggregated Summary as a single JSON document.
+type JSONReporter struct{}
+
+func (JSONReporter) Report(w io.Writer, results []CheckResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(summarize(results))
+}
+
+// TextReporter writes one human-readable line per result, matching the
+// original [FAIL]/[SUCCESS] console format.
+type TextReporter struct{}
+
+func (TextReporter) Report(w io.Writer, results []CheckResult) error {
+	for _, r := range results {
+		if r.Kind == ErrorKindNone {
+			if _, err := fmt.Fprintf(w, "[SUCCESS] %s - Status: %d (%s)\n", r.URL, r.StatusCode, r.Latency); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "[FAIL] %s - Kind: %s - Error: %s\n", r.URL, r.KindName, r.Error); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrometheusReporter writes results in the Prometheus textfile
+// collector format (https://github.com/prometheus/node_exporter#textfile-collector).
+type PrometheusReporter struct{}
+
+func (PrometheusReporter) Report(w io.Writer, results []CheckResult) error {
+	if _, err := fmt.Fprintln(w, "# HELP sre_check_up Whether the last check of the URL succeeded (1) or not (0)."); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "# TYPE sre_check_up gauge"); err != nil {
+		return err
+	}
+	for _, r := range results {
+		up := 0
+		if r.Kind == ErrorKindNone {
+			up = 1
+		}
+		if _, err := fmt.Fprintf(w, "sre_check_up{url=%q} %d\n", r.URL, up); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintln(w, "# HELP sre_check_latency_seconds Latency of the last check of the URL."); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "# TYPE sre_check_latency_seconds gauge"); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if _, err := fmt.Fprintf(w, "sre_check_latency_seconds{url=%q} %f\n", r.URL, r.Latency.Seconds()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
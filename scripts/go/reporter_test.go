@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+func TestSummarizeStatusOK(t *testing.T) {
+	results := []CheckResult{
+		{URL: "a", Kind: ErrorKindNone, KindName: "none"},
+		{URL: "b", Kind: ErrorKindNone, KindName: "none"},
+	}
+	s := summarize(results)
+	if s.Status != "ok" || s.Failed != 0 || s.Total != 2 {
+		t.Errorf("summarize(all ok) = %+v, want status=ok failed=0 total=2", s)
+	}
+}
+
+func TestSummarizeStatusDown(t *testing.T) {
+	results := []CheckResult{
+		{URL: "a", Kind: ErrorKindTimeout, KindName: "timeout"},
+		{URL: "b", Kind: ErrorKindDNS, KindName: "dns"},
+	}
+	s := summarize(results)
+	if s.Status != "down" || s.Failed != 2 {
+		t.Errorf("summarize(all failed) = %+v, want status=down failed=2", s)
+	}
+}
+
+func TestSummarizeStatusDegraded(t *testing.T) {
+	results := []CheckResult{
+		{URL: "a", Kind: ErrorKindNone, KindName: "none"},
+		{URL: "b", Kind: ErrorKindTimeout, KindName: "timeout"},
+	}
+	s := summarize(results)
+	if s.Status != "degraded" || s.Failed != 1 || s.Total != 2 {
+		t.Errorf("summarize(mixed) = %+v, want status=degraded failed=1 total=2", s)
+	}
+	if s.ByKind["timeout"] != 1 || s.ByKind["none"] != 1 {
+		t.Errorf("summarize(mixed).ByKind = %+v, want 1 each of none/timeout", s.ByKind)
+	}
+}
+
+func TestSummarizeEmpty(t *testing.T) {
+	s := summarize(nil)
+	if s.Status != "ok" || s.Total != 0 {
+		t.Errorf("summarize(nil) = %+v, want status=ok total=0", s)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrorKind classifies the failure mode of a check so callers can
+// filter or count results without parsing error strings.
+type ErrorKind int
+
+const (
+	ErrorKindNone ErrorKind = iota
+	ErrorKindTimeout
+	ErrorKindDNS
+	ErrorKindConnRefused
+	ErrorKindTLS
+	ErrorKindHTTP4xx
+	ErrorKindHTTP5xx
+	ErrorKindOther
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindNone:
+		return "none"
+	case ErrorKindTimeout:
+		return "timeout"
+	case ErrorKindDNS:
+		return "dns"
+	case ErrorKindConnRefused:
+		return "conn_refused"
+	case ErrorKindTLS:
+		return "tls"
+	case ErrorKindHTTP4xx:
+		return "http_4xx"
+	case ErrorKindHTTP5xx:
+		return "http_5xx"
+	default:
+		return "other"
+	}
+}
+
+// CheckResult is the structured outcome of probing a single URL.
+type CheckResult struct {
+	URL        string          `json:"url"`
+	StatusCode int             `json:"status_code,omitempty"`
+	Latency    time.Duration   `json:"latency"`
+	Error      string          `json:"error,omitempty"`
+	Kind       ErrorKind       `json:"-"`
+	KindName   string          `json:"kind"`
+	Attempts   []AttemptResult `json:"attempts,omitempty"`
+}
+
+// AttemptResult records the outcome of a single attempt within a
+// retried check, so callers can see backoff behavior in the report.
+type AttemptResult struct {
+	StatusCode int           `json:"status_code,omitempty"`
+	Latency    time.Duration `json:"latency"`
+	Error      string        `json:"error,omitempty"`
+	Phase      string        `json:"phase,omitempty"` // dial/tls/headers/body phase in flight on failure
+}
+
+// classifyErr inspects a network/transport error and buckets it into an
+// ErrorKind. statusCode is used when err is nil but the response carries
+// a 4xx/5xx status.
+func classifyErr(err error, statusCode int) ErrorKind {
+	if err == nil {
+		switch {
+		case statusCode >= 500:
+			return ErrorKindHTTP5xx
+		case statusCode >= 400:
+			return ErrorKindHTTP4xx
+		default:
+			return ErrorKindNone
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorKindTimeout
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrorKindDNS
+	}
+
+	var certErr x509.UnknownAuthorityError
+	var hostErr x509.HostnameError
+	var certInvalidErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) || errors.As(err, &hostErr) || errors.As(err, &certInvalidErr) {
+		return ErrorKindTLS
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		if errors.Is(opErr.Err, syscall.ECONNREFUSED) {
+			return ErrorKindConnRefused
+		}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return classifyErr(urlErr.Err, statusCode)
+	}
+
+	return ErrorKindOther
+}
+
+func newCheckResult(u string, statusCode int, latency time.Duration, err error, attempts []AttemptResult) CheckResult {
+	kind := classifyErr(err, statusCode)
+	r := CheckResult{
+		URL:        u,
+		StatusCode: statusCode,
+		Latency:    latency,
+		Kind:       kind,
+		KindName:   kind.String(),
+		Attempts:   attempts,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
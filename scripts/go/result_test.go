@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+)
+
+func TestClassifyErrStatusBuckets(t *testing.T) {
+	cases := []struct {
+		status int
+		want   ErrorKind
+	}{
+		{200, ErrorKindNone},
+		{404, ErrorKindHTTP4xx},
+		{500, ErrorKindHTTP5xx},
+		{503, ErrorKindHTTP5xx},
+	}
+	for _, c := range cases {
+		if got := classifyErr(nil, c.status); got != c.want {
+			t.Errorf("classifyErr(nil, %d) = %v, want %v", c.status, got, c.want)
+		}
+	}
+}
+
+func TestClassifyErrTimeout(t *testing.T) {
+	err := &url.Error{Op: "Get", URL: "https://example.com", Err: timeoutErr{}}
+	if got := classifyErr(err, 0); got != ErrorKindTimeout {
+		t.Errorf("classifyErr(timeout) = %v, want %v", got, ErrorKindTimeout)
+	}
+}
+
+func TestClassifyErrDNS(t *testing.T) {
+	err := &net.DNSError{Err: "no such host", Name: "nonexistent.invalid"}
+	if got := classifyErr(err, 0); got != ErrorKindDNS {
+		t.Errorf("classifyErr(dns) = %v, want %v", got, ErrorKindDNS)
+	}
+}
+
+func TestClassifyErrConnRefused(t *testing.T) {
+	err := &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}
+	if got := classifyErr(err, 0); got != ErrorKindConnRefused {
+		t.Errorf("classifyErr(conn refused) = %v, want %v", got, ErrorKindConnRefused)
+	}
+}
+
+func TestClassifyErrWrappedInURLError(t *testing.T) {
+	inner := &net.DNSError{Err: "no such host", Name: "nonexistent.invalid"}
+	err := &url.Error{Op: "Get", URL: "https://example.com", Err: inner}
+	if got := classifyErr(err, 0); got != ErrorKindDNS {
+		t.Errorf("classifyErr(wrapped dns) = %v, want %v", got, ErrorKindDNS)
+	}
+}
+
+func TestClassifyErrOther(t *testing.T) {
+	if got := classifyErr(errors.New("boom"), 0); got != ErrorKindOther {
+		t.Errorf("classifyErr(other) = %v, want %v", got, ErrorKindOther)
+	}
+}
+
+// timeoutErr is a minimal net.Error whose Timeout() reports true.
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how checkUrl retries a failed request.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first, 0 or 1 disables retrying
+	BaseDelay   time.Duration // delay before the first retry
+	MaxDelay    time.Duration // upper bound on any single backoff delay
+	Jitter      bool          // randomize each delay in [0, delay]
+}
+
+// DefaultRetryPolicy retries network errors, 5xx, and 429 up to 4 times
+// total with a doubling backoff capped at 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      true,
+	}
+}
+
+// retryableStatus reports whether statusCode warrants a retry. 4xx
+// statuses are not retryable except 408 (timeout) and 429 (rate limit).
+func retryableStatus(statusCode int) bool {
+	if statusCode == 0 {
+		return true // network error, no response received
+	}
+	if statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if statusCode >= 500 {
+		return true
+	}
+	return false
+}
+
+// backoffDelay returns the delay to wait before the given retry attempt
+// (1-indexed: the delay before the 2nd overall attempt is attempt=1).
+func backoffDelay(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay << uint(attempt-1)
+	if delay <= 0 || delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if policy.Jitter {
+		delay = time.Duration(rand.Int63n(int64(delay) + 1))
+	}
+	return delay
+}
+
+// parseRetryAfter parses a Retry-After header value, which per RFC 9110
+// is either a number of seconds or an HTTP-date.
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if d := when.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepOrDone waits for d or returns ctx.Err() if ctx is canceled first.
+func sleepOrDone(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
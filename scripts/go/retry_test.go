@@ -0,0 +1,90 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryableStatus(t *testing.T) {
+	cases := []struct {
+		status int
+		want   bool
+	}{
+		{0, true},
+		{408, true},
+		{429, true},
+		{500, true},
+		{503, true},
+		{400, false},
+		{404, false},
+		{200, false},
+	}
+	for _, c := range cases {
+		if got := retryableStatus(c.status); got != c.want {
+			t.Errorf("retryableStatus(%d) = %v, want %v", c.status, got, c.want)
+		}
+	}
+}
+
+func TestBackoffDelayCapsAtMaxDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 2 * time.Second, Jitter: false}
+	if got := backoffDelay(policy, 5); got != policy.MaxDelay {
+		t.Errorf("backoffDelay(attempt=5) = %v, want %v (capped)", got, policy.MaxDelay)
+	}
+}
+
+func TestBackoffDelayWithJitterStaysInBounds(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: true}
+	for i := 0; i < 20; i++ {
+		got := backoffDelay(policy, 2)
+		if got < 0 || got > policy.MaxDelay {
+			t.Fatalf("backoffDelay with jitter = %v, want in [0, %v]", got, policy.MaxDelay)
+		}
+	}
+}
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	now := time.Now()
+	d, ok := parseRetryAfter("5", now)
+	if !ok || d != 5*time.Second {
+		t.Errorf("parseRetryAfter(\"5\") = (%v, %v), want (5s, true)", d, ok)
+	}
+}
+
+func TestParseRetryAfterNegativeSeconds(t *testing.T) {
+	now := time.Now()
+	if _, ok := parseRetryAfter("-5", now); ok {
+		t.Errorf("parseRetryAfter(\"-5\") should be rejected")
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := now.Add(90 * time.Second).Format(http.TimeFormat)
+	d, ok := parseRetryAfter(future, now)
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q) not ok", future)
+	}
+	if d <= 0 || d > 91*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want ~90s", future, d)
+	}
+}
+
+func TestParseRetryAfterPastDate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-90 * time.Second).Format(http.TimeFormat)
+	d, ok := parseRetryAfter(past, now)
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q) not ok", past)
+	}
+	if d != 0 {
+		t.Errorf("parseRetryAfter(%q) = %v, want 0 (already past)", past, d)
+	}
+}
+
+func TestParseRetryAfterInvalid(t *testing.T) {
+	if _, ok := parseRetryAfter("not-a-valid-value", time.Now()); ok {
+		t.Errorf("parseRetryAfter should reject unparseable values")
+	}
+}